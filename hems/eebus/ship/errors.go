@@ -0,0 +1,11 @@
+package ship
+
+import "errors"
+
+// Sentinel errors for conditions callers (e.g. the gRPC façade) need to
+// distinguish via errors.Is instead of matching on message text.
+var (
+	ErrPinMismatch      = errors.New("pin: remote pin mismatched")
+	ErrReadWriteTimeout = errors.New("transport: read/write timeout")
+	ErrConnectionClose  = errors.New("connection closed by peer")
+)
@@ -0,0 +1,57 @@
+package ship
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Publisher publishes SHIP protocol events to an external message bus
+// (e.g. NATS, MQTT) and allows outbound messages to be injected back into
+// a session from that bus. Implementations live under hems/eebus/ship/pubsub.
+type Publisher interface {
+	// Publish sends v, marshalled as JSON, to subject
+	Publish(subject string, v interface{}) error
+
+	// Subscribe delivers messages received on subject to handler until the
+	// returned unsubscribe func is called
+	Subscribe(subject string, handler func([]byte)) (unsubscribe func() error, err error)
+}
+
+// subject builds the evcc.ship.<ski>.<suffix> subject for this session
+func (c *Server) subject(suffix string) string {
+	return fmt.Sprintf("evcc.ship.%s.%s", c.Ski, suffix)
+}
+
+// publishState emits a handshake lifecycle event
+func (c *Server) publishState(state string) {
+	if c.Publisher == nil {
+		return
+	}
+
+	_ = c.Publisher.Publish(c.subject("state"), state)
+}
+
+// publishMessage emits a decoded SHIP message, keyed by its Go type name
+func (c *Server) publishMessage(msgType string, v interface{}) {
+	if c.Publisher == nil {
+		return
+	}
+
+	_ = c.Publisher.Publish(c.subject(msgType), v)
+}
+
+// subscribeOutbound injects messages received on the session's "out" subject
+// into the connection via WriteJSON, decoupling outbound SHIP traffic from
+// the in-process Handler callback
+func (c *Server) subscribeOutbound() (func() error, error) {
+	if c.Publisher == nil {
+		return func() error { return nil }, nil
+	}
+
+	return c.Publisher.Subscribe(c.subject("out"), func(b []byte) {
+		// No request context carries across a Publisher subscription callback,
+		// so the resulting span is a root span rather than a child of Serve's.
+		_ = c.WriteJSON(context.Background(), CmiTypeControl, json.RawMessage(b))
+	})
+}
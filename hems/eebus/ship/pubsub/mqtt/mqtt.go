@@ -0,0 +1,60 @@
+// Package mqtt implements ship.Publisher on top of an MQTT client
+package mqtt
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/andig/evcc/hems/eebus/ship"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// Publisher is a ship.Publisher backed by an MQTT client
+type Publisher struct {
+	client paho.Client
+}
+
+var _ ship.Publisher = (*Publisher)(nil)
+
+// New returns a Publisher using the given, already-connected MQTT client
+func New(client paho.Client) *Publisher {
+	return &Publisher{client: client}
+}
+
+// topic maps a dotted SHIP subject (evcc.ship.<ski>.out) to an MQTT topic (evcc/ship/<ski>/out)
+func topic(subject string) string {
+	return strings.ReplaceAll(subject, ".", "/")
+}
+
+// Publish implements ship.Publisher
+func (p *Publisher) Publish(subject string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	token := p.client.Publish(topic(subject), 0, false, b)
+	token.Wait()
+
+	return token.Error()
+}
+
+// Subscribe implements ship.Publisher
+func (p *Publisher) Subscribe(subject string, handler func([]byte)) (func() error, error) {
+	t := topic(subject)
+
+	token := p.client.Subscribe(t, 0, func(_ paho.Client, msg paho.Message) {
+		handler(msg.Payload())
+	})
+	token.Wait()
+
+	if err := token.Error(); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		token := p.client.Unsubscribe(t)
+		token.Wait()
+		return token.Error()
+	}, nil
+}
@@ -0,0 +1,17 @@
+package mqtt
+
+import "testing"
+
+func TestTopic(t *testing.T) {
+	cases := map[string]string{
+		"evcc.ship.abc.out":   "evcc/ship/abc/out",
+		"evcc.ship.abc.state": "evcc/ship/abc/state",
+		"noseparator":         "noseparator",
+	}
+
+	for subject, want := range cases {
+		if got := topic(subject); got != want {
+			t.Errorf("topic(%q) = %q, want %q", subject, got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package nats implements ship.Publisher on top of a NATS connection
+package nats
+
+import (
+	"encoding/json"
+
+	"github.com/andig/evcc/hems/eebus/ship"
+	"github.com/nats-io/nats.go"
+)
+
+// Publisher is a ship.Publisher backed by a NATS connection
+type Publisher struct {
+	conn *nats.Conn
+}
+
+var _ ship.Publisher = (*Publisher)(nil)
+
+// New connects to the given NATS server and returns a ready-to-use Publisher
+func New(url string) (*Publisher, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Publisher{conn: conn}, nil
+}
+
+// Publish implements ship.Publisher
+func (p *Publisher) Publish(subject string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return p.conn.Publish(subject, b)
+}
+
+// Subscribe implements ship.Publisher
+func (p *Publisher) Subscribe(subject string, handler func([]byte)) (func() error, error) {
+	sub, err := p.conn.Subscribe(subject, func(msg *nats.Msg) {
+		handler(msg.Data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sub.Unsubscribe, nil
+}
@@ -2,18 +2,31 @@ package ship
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Server is the SHIP server
 type Server struct {
 	Log Logger
 	Pin string
+	// Ski is the peer's SKI, used to namespace Publisher subjects
+	Ski string
 	*Transport
 	Handler func(req interface{}) error
+	// Publisher, if set, publishes protocol events and accepts outbound
+	// messages injected from an external message bus
+	Publisher Publisher
+
+	// unsubscribeOutbound stops the subscription started by subscribeOutbound,
+	// set during Handshake and released in Close
+	unsubscribeOutbound func() error
 }
 
 func (c *Server) log() Logger {
@@ -23,24 +36,30 @@ func (c *Server) log() Logger {
 	return c.Log
 }
 
-func (c *Server) init() error {
+func (c *Server) init(ctx context.Context) error {
+	_, span := tracer.Start(ctx, "ship.init")
+	defer span.End()
+
 	init := []byte{CmiTypeInit, 0x00}
 
 	// CMI_STATE_CLIENT_EVALUATE
 	msg, err := c.readBinary()
 	if err != nil {
-		return err
+		return recordErr(span, err)
 	}
 
 	if bytes.Compare(init, msg) != 0 {
-		return fmt.Errorf("init: invalid response: %0 x", msg)
+		return recordErr(span, fmt.Errorf("init: invalid response: %0 x", msg))
 	}
 
 	// CMI_STATE_CLIENT_SEND
-	return c.writeBinary(init)
+	return recordErr(span, c.writeBinary(init))
 }
 
-func (c *Server) protocolHandshake() error {
+func (c *Server) protocolHandshake(ctx context.Context) error {
+	_, span := tracer.Start(ctx, "ship.protocolHandshake")
+	defer span.End()
+
 	var req CmiHandshakeMsg
 	typ, err := c.readJSON(&req)
 
@@ -74,10 +93,13 @@ func (c *Server) protocolHandshake() error {
 		_, err = c.handshakeReceiveSelect()
 	}
 
-	return err
+	return recordErr(span, err)
 }
 
-func (c *Server) pinState() error {
+func (c *Server) pinState(ctx context.Context) error {
+	_, span := tracer.Start(ctx, "ship.pinState")
+	defer span.End()
+
 	pinState := PinStateNone
 	var inputPermission string
 	if c.Pin != "" {
@@ -96,10 +118,10 @@ func (c *Server) pinState() error {
 	err := c.writeJSON(CmiTypeControl, req)
 
 	// verify client pin
-	var pi ConnectionPinInput
-	for err == nil && pi.Pin != c.Pin {
+	if err == nil {
 		var resp CmiConnectionPinInput
-		typ, err := c.readJSON(&resp)
+		var typ byte
+		typ, err = c.readJSON(&resp)
 
 		if err == nil && typ != CmiTypeControl {
 			err = errors.New("pin: invalid type")
@@ -109,92 +131,153 @@ func (c *Server) pinState() error {
 			err = errors.New("pin: invalid length")
 		}
 
-		if err == nil {
-			pi = resp.ConnectionPinInput[0]
-
-			// signal error to client
-			if pi.Pin != c.Pin {
-				req := CmiConnectionPinError{
-					ConnectionPinError: []ConnectionPinError{
-						{
-							Error: 1,
-						},
+		if err == nil && resp.ConnectionPinInput[0].Pin != c.Pin {
+			// signal error to client, then fail the handshake
+			errReq := CmiConnectionPinError{
+				ConnectionPinError: []ConnectionPinError{
+					{
+						Error: 1,
 					},
-				}
-				err = c.writeJSON(CmiTypeControl, req)
+				},
+			}
+
+			if werr := c.writeJSON(CmiTypeControl, errReq); werr != nil {
+				err = werr
+			} else {
+				err = fmt.Errorf("pin: %w", ErrPinMismatch)
 			}
 		}
 	}
 
-	return err
+	return recordErr(span, err)
 }
 
 // Close performs ordered close of server connection
 func (c *Server) Close() error {
+	if c.unsubscribeOutbound != nil {
+		_ = c.unsubscribeOutbound()
+	}
 	return c.close()
 }
 
-// Serve performs the server connection handshake
-func (c *Server) Serve(conn *websocket.Conn) error {
+// Handshake performs init/hello/protocolHandshake/pinState/accessMethods on the
+// given connection and leaves the server ready for the message loop. It is
+// exported separately from Serve so that callers (e.g. the gRPC façade) can
+// drive the message loop themselves instead of blocking inside Serve. Every
+// phase is traced as a child of ctx's span, if any.
+func (c *Server) Handshake(ctx context.Context, conn *websocket.Conn) error {
 	c.Transport = &Transport{
 		Conn: conn,
 		Log:  c.log(),
 	}
 
-	err := c.init()
+	err := c.init(ctx)
 	if err == nil {
-		err = c.hello()
+		c.publishState("init")
+		_, span := tracer.Start(ctx, "ship.hello")
+		err = recordErr(span, c.hello())
+		span.End()
 	}
 	if err == nil {
-		err = c.protocolHandshake()
+		c.publishState("hello")
+		err = c.protocolHandshake(ctx)
 	}
 	if err == nil {
-		err = c.pinState()
+		c.publishState("protocolHandshake")
+		err = c.pinState(ctx)
 	}
 	if err == nil {
+		c.publishState("pinState")
+		_, span := tracer.Start(ctx, "ship.accessMethods")
 		err = c.accessMethodsRequest()
+		if err == nil {
+			err = c.accessMethods()
+		}
+		err = recordErr(span, err)
+		span.End()
 	}
 	if err == nil {
-		err = c.accessMethods()
+		c.publishState("accessMethods")
+		c.unsubscribeOutbound, err = c.subscribeOutbound()
 	}
 
-	if err == nil {
-		for {
-			var typ byte
-			var req CmiMessage
-			typ, err = c.waitJSON(&req)
-			if err != nil {
-				break
-			}
+	if err != nil {
+		_ = c.Close()
+	}
 
-			var typed interface{}
-			typed, err = DecodeMessage(req)
+	return err
+}
 
-			c.log().Printf("serv: %d %+v", typ, typed)
+// Next blocks for the next decoded SHIP message, dispatching Handler for
+// non-terminal messages. It returns the decoded message and true once a
+// ConnectionClose has been accepted, signalling the caller to stop. The
+// message span is a child of ctx's span, if any.
+func (c *Server) Next(ctx context.Context) (interface{}, bool, error) {
+	_, span := tracer.Start(ctx, "ship.message")
+	defer span.End()
 
-			if err != nil {
-				break
-			}
+	var req CmiMessage
+	typ, err := c.waitJSON(&req)
+	if err != nil {
+		return nil, false, recordErr(span, err)
+	}
+	span.SetAttributes(attribute.Int("ship.cmi_type", int(typ)))
 
-			if _, ok := typed.(ConnectionClose); ok {
-				return c.acceptClose()
-			}
+	typed, err := DecodeMessage(req)
+	c.log().Printf("serv: %d %+v", typ, typed)
+	if err != nil {
+		return nil, false, recordErr(span, err)
+	}
+	span.SetAttributes(attribute.String("ship.go_type", fmt.Sprintf("%T", typed)))
 
-			if c.Handler == nil {
-				err = errors.New("no handler")
-				break
-			}
+	c.publishMessage(strings.TrimPrefix(fmt.Sprintf("%T", typed), "ship."), typed)
 
-			if err = c.Handler(typed); err != nil {
-				break
-			}
+	if _, ok := typed.(ConnectionClose); ok {
+		return typed, true, recordErr(span, c.acceptClose())
+	}
+
+	if c.Handler == nil {
+		return typed, false, recordErr(span, errors.New("no handler"))
+	}
+
+	return typed, false, recordErr(span, c.Handler(typed))
+}
+
+// WriteJSON writes a typed SHIP message to the connected client. The span is
+// a child of ctx's span, if any.
+func (c *Server) WriteJSON(ctx context.Context, typ byte, v interface{}) error {
+	_, span := tracer.Start(ctx, "ship.WriteJSON")
+	defer span.End()
+
+	return recordErr(span, c.writeJSON(typ, v))
+}
+
+// Serve performs the server connection handshake and message loop
+func (c *Server) Serve(conn *websocket.Conn) error {
+	ctx, span := tracer.Start(context.Background(), "ship.Serve", trace.WithAttributes(
+		attribute.String("ship.ski", c.Ski),
+		attribute.String("ship.remote_addr", conn.RemoteAddr().String()),
+	))
+	defer span.End()
+
+	err := c.Handshake(ctx, conn)
+	if err == nil {
+		span.SetAttributes(attribute.String("ship.protocol_format", ProtocolHandshakeFormatJSON))
+	}
+
+	for err == nil {
+		var done bool
+		_, done, err = c.Next(ctx)
+		if done {
+			_ = c.Close()
+			return recordErr(span, err)
 		}
 	}
 
-	// close connection if handshake or hello fails
+	// close connection if handshake, hello or message loop fails
 	if err != nil {
 		_ = c.Close()
 	}
 
-	return err
-}
\ No newline at end of file
+	return recordErr(span, err)
+}
@@ -0,0 +1,32 @@
+package shipgrpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the gRPC content-subtype under which jsonCodec is registered.
+// ship.proto's messages are plain Go structs rather than protoc-gen-go
+// output (no protoc toolchain runs in this tree), so Connect/Session are
+// carried over a real grpc.Server/grpc.ClientConn using JSON instead of the
+// binary protobuf wire format.
+const codecName = "shipgrpc-json"
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,179 @@
+// Package shipgrpc wraps ship.Server in a gRPC service: Connect dials a peer
+// and runs the handshake, Session streams the decoded messages and accepts
+// commands to write back.
+//
+// Known limitation: ship.proto's messages are carried as JSON via the
+// jsonCodec registered in codec.go rather than protoc-generated protobuf
+// bindings (no protoc toolchain is available in this tree). That codec is
+// only registered by this package, so today only a Go client importing
+// shipgrpc and dialing with grpc.CallContentSubtype("shipgrpc-json") can
+// talk to this service — a stock grpc-python/grpc-node client cannot
+// negotiate it. Regenerating ship.proto with protoc-gen-go/
+// protoc-gen-go-grpc and switching ServiceDesc/Client to the generated
+// binary codec is required before non-Go controllers can actually drive
+// this without linking this Go package.
+package shipgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/andig/evcc/hems/eebus/ship"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Service implements ShipServiceServer by wrapping ship.Server sessions
+type Service struct {
+	UnimplementedShipServiceServer
+
+	mu       sync.Mutex
+	sessions map[string]*ship.Server
+	log      ship.Logger
+}
+
+var _ ShipServiceServer = (*Service)(nil)
+
+// NewService creates a Service ready to accept Connect calls
+func NewService(log ship.Logger) *Service {
+	return &Service{
+		sessions: make(map[string]*ship.Server),
+		log:      log,
+	}
+}
+
+// Connect performs the SHIP handshake against target and registers the
+// resulting session for use by Session
+func (s *Service) Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, req.Target, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "dial: %v", err)
+	}
+
+	srv := &ship.Server{
+		Log: s.log,
+		Pin: req.LocalPin,
+		// decoded messages reach the client through Session's stream; no
+		// local handler processes them
+		Handler: func(interface{}) error { return nil },
+	}
+
+	if err := srv.Handshake(ctx, conn); err != nil {
+		return nil, mapHandshakeError(err)
+	}
+
+	id := uuid.New().String()
+
+	s.mu.Lock()
+	s.sessions[id] = srv
+	s.mu.Unlock()
+
+	return &ConnectResponse{SessionID: id}, nil
+}
+
+// Session drains decoded SHIP messages from the session's Transport into the
+// stream as ShipEvents, and dispatches every ShipCommand received from the
+// stream through the server's Handler before writing it back with WriteJSON.
+func (s *Service) Session(stream ShipService_SessionServer) error {
+	cmd, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	srv, ok := s.sessions[cmd.SessionID]
+	s.mu.Unlock()
+	if !ok {
+		return status.Errorf(codes.NotFound, "unknown session: %s", cmd.SessionID)
+	}
+
+	// the session is only good for a single Session call; drop it and close
+	// the underlying connection once this stream ends, however it ends
+	defer func() {
+		s.mu.Lock()
+		delete(s.sessions, cmd.SessionID)
+		s.mu.Unlock()
+
+		_ = srv.Close()
+	}()
+
+	if len(cmd.Payload) > 0 {
+		if err := srv.WriteJSON(stream.Context(), ship.CmiTypeControl, json.RawMessage(cmd.Payload)); err != nil {
+			return err
+		}
+	}
+
+	errc := make(chan error, 2)
+
+	// outbound: decoded SHIP messages -> ShipEvent
+	go func() {
+		for {
+			typed, done, err := srv.Next(stream.Context())
+			if err != nil {
+				errc <- mapHandshakeError(err)
+				return
+			}
+
+			payload, err := json.Marshal(typed)
+			if err != nil {
+				errc <- status.Errorf(codes.Internal, "marshal: %v", err)
+				return
+			}
+
+			event := &ShipEvent{
+				SessionID: cmd.SessionID,
+				CmiType:   strings.TrimPrefix(fmt.Sprintf("%T", typed), "ship."),
+				Payload:   payload,
+			}
+			if err := stream.Send(event); err != nil {
+				errc <- err
+				return
+			}
+
+			if done {
+				errc <- nil
+				return
+			}
+		}
+	}()
+
+	// inbound: ShipCommand -> WriteJSON
+	go func() {
+		for {
+			cmd, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+
+			if err := srv.WriteJSON(stream.Context(), ship.CmiTypeControl, json.RawMessage(cmd.Payload)); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+
+	return <-errc
+}
+
+// mapHandshakeError maps SHIP handshake/transport errors to gRPC status codes
+func mapHandshakeError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ship.ErrPinMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ship.ErrReadWriteTimeout):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, ship.ErrConnectionClose):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
@@ -0,0 +1,164 @@
+package shipgrpc
+
+// This file mirrors the client/server bindings protoc-gen-go-grpc would
+// generate from ship.proto for the ShipService RPCs: a typed client, a
+// ShipServiceServer interface with an Unimplemented embed, the bidi stream
+// wrappers around grpc.ClientStream/grpc.ServerStream, and the ServiceDesc
+// that wires method names to handlers.
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codecOpt forces calls made through this package's client onto the JSON
+// codec registered in codec.go
+var codecOpt = grpc.CallContentSubtype(codecName)
+
+// ShipServiceClient is the client API for the ShipService RPCs
+type ShipServiceClient interface {
+	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
+	Session(ctx context.Context, opts ...grpc.CallOption) (ShipService_SessionClient, error)
+}
+
+type shipServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewShipServiceClient returns a ShipServiceClient bound to cc
+func NewShipServiceClient(cc grpc.ClientConnInterface) ShipServiceClient {
+	return &shipServiceClient{cc}
+}
+
+func (c *shipServiceClient) Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error) {
+	out := new(ConnectResponse)
+	if err := c.cc.Invoke(ctx, "/shipgrpc.ShipService/Connect", in, out, append(opts, codecOpt)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipServiceClient) Session(ctx context.Context, opts ...grpc.CallOption) (ShipService_SessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &shipServiceServiceDesc.Streams[0], "/shipgrpc.ShipService/Session", append(opts, codecOpt)...)
+	if err != nil {
+		return nil, err
+	}
+	return &shipServiceSessionClient{stream}, nil
+}
+
+// ShipService_SessionClient is the client side of the Session bidi stream
+type ShipService_SessionClient interface {
+	Send(*ShipCommand) error
+	Recv() (*ShipEvent, error)
+	grpc.ClientStream
+}
+
+type shipServiceSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *shipServiceSessionClient) Send(m *ShipCommand) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *shipServiceSessionClient) Recv() (*ShipEvent, error) {
+	m := new(ShipEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ShipServiceServer is the server API for the ShipService RPCs
+type ShipServiceServer interface {
+	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
+	Session(ShipService_SessionServer) error
+}
+
+// UnimplementedShipServiceServer can be embedded to get forward-compatible implementations
+type UnimplementedShipServiceServer struct{}
+
+func (UnimplementedShipServiceServer) Connect(context.Context, *ConnectRequest) (*ConnectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Connect not implemented")
+}
+
+func (UnimplementedShipServiceServer) Session(ShipService_SessionServer) error {
+	return status.Error(codes.Unimplemented, "method Session not implemented")
+}
+
+// ShipService_SessionServer is the server side of the Session bidi stream
+type ShipService_SessionServer interface {
+	Send(*ShipEvent) error
+	Recv() (*ShipCommand, error)
+	grpc.ServerStream
+}
+
+type shipServiceSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *shipServiceSessionServer) Send(m *ShipEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *shipServiceSessionServer) Recv() (*ShipCommand, error) {
+	m := new(ShipCommand)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RegisterShipServiceServer registers srv on s
+func RegisterShipServiceServer(s grpc.ServiceRegistrar, srv ShipServiceServer) {
+	s.RegisterService(&shipServiceServiceDesc, srv)
+}
+
+func shipServiceConnectHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(ShipServiceServer).Connect(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shipgrpc.ShipService/Connect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipServiceServer).Connect(ctx, req.(*ConnectRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func shipServiceSessionHandler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShipServiceServer).Session(&shipServiceSessionServer{stream})
+}
+
+// shipServiceServiceDesc mirrors the grpc.ServiceDesc protoc-gen-go-grpc would emit for ship.proto
+var shipServiceServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shipgrpc.ShipService",
+	HandlerType: (*ShipServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connect",
+			Handler:    shipServiceConnectHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Session",
+			Handler:       shipServiceSessionHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "hems/eebus/ship/shipgrpc/ship.proto",
+}
@@ -0,0 +1,30 @@
+package shipgrpc
+
+// The message types below mirror ship.proto's ConnectRequest/ConnectResponse/
+// ShipEvent/ShipCommand. The RPC plumbing (ShipServiceServer, the Session
+// stream wrappers, the ServiceDesc) lives in ship_grpc.go.
+
+// ConnectRequest carries the target address and optional local pin for Connect
+type ConnectRequest struct {
+	Target   string
+	LocalPin string
+}
+
+// ConnectResponse carries the session ID assigned by Connect
+type ConnectResponse struct {
+	SessionID string
+}
+
+// ShipEvent is a SHIP message decoded off the wire, sent server -> client
+type ShipEvent struct {
+	SessionID string
+	CmiType   string
+	Payload   []byte
+}
+
+// ShipCommand is a SHIP message to be dispatched and written to the peer, sent client -> server
+type ShipCommand struct {
+	SessionID string
+	CmiType   string
+	Payload   []byte
+}
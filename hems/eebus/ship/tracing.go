@@ -0,0 +1,29 @@
+package ship
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer is a no-op by default (the global otel TracerProvider is a no-op
+// until an embedder registers a real one via otel.SetTracerProvider), so
+// existing embedders see no behavior change.
+var tracer = otel.Tracer("github.com/andig/evcc/hems/eebus/ship")
+
+// RecordErr records err on span, if any, and sets the span status accordingly.
+// It returns err unchanged so it can wrap a return statement. Exported so
+// that subpackages (e.g. transport) sharing the same tracing convention
+// don't need their own copy.
+func RecordErr(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// recordErr is the package-local alias used throughout ship
+func recordErr(span trace.Span, err error) error {
+	return RecordErr(span, err)
+}
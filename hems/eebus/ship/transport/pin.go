@@ -1,9 +1,12 @@
 package transport
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/andig/evcc/hems/eebus/ship"
 	"github.com/andig/evcc/hems/eebus/ship/message"
 )
 
@@ -11,17 +14,16 @@ import (
 func (c *Transport) readPinState() (message.ConnectionPinState, error) {
 	timer := time.NewTimer(CmiReadWriteTimeout)
 	msg, err := c.ReadMessage(timer.C)
+	if err != nil {
+		return message.ConnectionPinState{}, fmt.Errorf("%w: %v", ship.ErrReadWriteTimeout, err)
+	}
 
 	switch typed := msg.(type) {
 	case message.ConnectionPinState:
-		return typed, err
+		return typed, nil
 
 	default:
-		if err == nil {
-			err = errors.New("pin: invalid type")
-		}
-
-		return message.ConnectionPinState{}, err
+		return message.ConnectionPinState{}, errors.New("pin: invalid type")
 	}
 }
 
@@ -32,7 +34,12 @@ const (
 	pinCompleted = pinReceived | pinSent
 )
 
-func (c *Transport) PinState(local, remote string) error {
+// PinState runs the PIN exchange over c. The span is a child of ctx's span,
+// if any, matching the rest of this tree's handshake tracing.
+func (c *Transport) PinState(ctx context.Context, local, remote string) error {
+	_, span := tracer.Start(ctx, "transport.PinState")
+	defer span.End()
+
 	pinState := message.ConnectionPinState{
 		PinState: message.PinStateNone,
 	}
@@ -55,6 +62,7 @@ func (c *Transport) PinState(local, remote string) error {
 		var msg interface{}
 		msg, err = c.ReadMessage(timer.C)
 		if err != nil {
+			err = fmt.Errorf("%w: %v", ship.ErrReadWriteTimeout, err)
 			break
 		}
 
@@ -85,15 +93,15 @@ func (c *Transport) PinState(local, remote string) error {
 			status |= pinSent
 
 		case message.ConnectionPinError:
-			err = errors.New("pin: remote pin mismatched")
+			err = fmt.Errorf("pin: %w", ship.ErrPinMismatch)
 
 		case message.ConnectionClose:
-			err = errors.New("pin: remote closed")
+			err = fmt.Errorf("pin: %w", ship.ErrConnectionClose)
 
 		default:
 			err = errors.New("pin: invalid type")
 		}
 	}
 
-	return err
-}
\ No newline at end of file
+	return recordErr(span, err)
+}
@@ -0,0 +1,19 @@
+package transport
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/andig/evcc/hems/eebus/ship"
+)
+
+// tracer is a no-op by default (the global otel TracerProvider is a no-op
+// until an embedder registers a real one via otel.SetTracerProvider), so
+// existing embedders see no behavior change.
+var tracer = otel.Tracer("github.com/andig/evcc/hems/eebus/ship/transport")
+
+// recordErr delegates to ship.RecordErr so both packages share one
+// implementation of the record-error-on-span convention.
+func recordErr(span trace.Span, err error) error {
+	return ship.RecordErr(span, err)
+}
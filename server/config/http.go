@@ -0,0 +1,19 @@
+package config
+
+import (
+	"net/http"
+)
+
+// JSONSchemaHandler serves the JSON Schema draft-07 document for the given configuration structure
+func JSONSchemaHandler(s interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, err := JSONSchema(s)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/schema+json")
+		_, _ = w.Write(b)
+	}
+}
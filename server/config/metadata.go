@@ -25,16 +25,23 @@ type description struct {
 
 // FieldMetadata is the meta data format for the type description
 type FieldMetadata struct {
-	Name     string          `json:"name"`
-	Type     string          `json:"type"`
-	Length   int             `json:"length,omitempty"`
-	SubType  string          `json:"subtype,omitempty"`
-	Required bool            `json:"required,omitempty"`
-	Hidden   bool            `json:"hidden,omitempty"`
-	Label    string          `json:"label,omitempty"`
-	Enum     []interface{}   `json:"enum,omitempty"`
-	Default  interface{}     `json:"default,omitempty"`
-	Children []FieldMetadata `json:"children,omitempty"`
+	Name      string          `json:"name"`
+	Type      string          `json:"type"`
+	Length    int             `json:"length,omitempty"`
+	SubType   string          `json:"subtype,omitempty"`
+	Required  bool            `json:"required,omitempty"`
+	Hidden    bool            `json:"hidden,omitempty"`
+	Label     string          `json:"label,omitempty"`
+	Unit      string          `json:"unit,omitempty"`
+	Enum      []interface{}   `json:"enum,omitempty"`
+	Min       *float64        `json:"min,omitempty"`
+	Max       *float64        `json:"max,omitempty"`
+	MinLength *int            `json:"minLength,omitempty"`
+	MaxLength *int            `json:"maxLength,omitempty"`
+	Pattern   string          `json:"pattern,omitempty"`
+	Step      *float64        `json:"step,omitempty"`
+	Default   interface{}     `json:"default,omitempty"`
+	Children  []FieldMetadata `json:"children,omitempty"`
 }
 
 // tagKey returns tag key's value or key name if value is empty
@@ -68,6 +75,32 @@ func enum(list []string) (enum []interface{}) {
 	return enum
 }
 
+// floatTag returns the first of the given validate tag keys parsed as a float64
+func floatTag(f *structs.Field, keys ...string) *float64 {
+	for _, key := range keys {
+		if val := tagKey(f, "validate", key); val != "" {
+			if n, err := strconv.ParseFloat(val, 64); err == nil {
+				return &n
+			}
+		}
+	}
+
+	return nil
+}
+
+// intTag returns the first of the given validate tag keys parsed as an int
+func intTag(f *structs.Field, keys ...string) *int {
+	for _, key := range keys {
+		if val := tagKey(f, "validate", key); val != "" {
+			if n, err := strconv.Atoi(val); err == nil {
+				return &n
+			}
+		}
+	}
+
+	return nil
+}
+
 // label is the exported field label
 func label(f *structs.Field) string {
 	val := tagKey(f, "ui", "de")
@@ -81,6 +114,24 @@ func label(f *structs.Field) string {
 	return val
 }
 
+// unit is the exported field unit, e.g. kWh
+func unit(f *structs.Field) string {
+	return tagKey(f, "ui", "unit")
+}
+
+// step is the suggested UI increment for numeric fields: whole steps for
+// integers and duration seconds, unconstrained for floats
+func step(f *structs.Field, typ string) *float64 {
+	switch typ {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", typeDuration:
+		n := 1.0
+		return &n
+	default:
+		return nil
+	}
+}
+
 // kind is the exported data type
 func kind(f *structs.Field) string {
 	val := f.Value()
@@ -152,7 +203,12 @@ func Annotate(s interface{}) (ds []FieldMetadata) {
 			// enums
 			if oneof := tagKey(f, "validate", "oneof"); oneof != "" {
 				d.Enum = enum(strings.Split(oneof, " "))
+			} else if oneof := tagKey(f, "validate", "oneofci"); oneof != "" {
+				d.Enum = enum(strings.Split(oneof, " "))
 			}
+
+			// unit
+			d.Unit = unit(f)
 		}
 
 		// add default values if not masked
@@ -160,21 +216,39 @@ func Annotate(s interface{}) (ds []FieldMetadata) {
 			d.Default = value(f)
 		}
 
+		// numeric bounds, also applied to duration fields (in seconds)
+		switch d.Type {
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64", typeDuration:
+			d.Min = floatTag(f, "gte", "min")
+			d.Max = floatTag(f, "lte", "max")
+			d.Step = step(f, d.Type)
+
+		case "string":
+			d.MinLength = intTag(f, "gte", "min", "len")
+			d.MaxLength = intTag(f, "lte", "max", "len")
+			d.Pattern = tagKey(f, "validate", "regexp")
+		}
+
 		switch f.Kind() {
 		case reflect.Interface, reflect.Func:
 			continue
 
 		case reflect.Ptr:
-			t := reflect.PtrTo(reflect.TypeOf(f.Value()))
+			t := reflect.TypeOf(f.Value()).Elem()
 			if t.String() != "provider.Config" {
 				continue
 			}
 			d.Type = typePlugin
 
 		case reflect.Slice:
+			d.MinLength = intTag(f, "gte", "len")
+			d.MaxLength = intTag(f, "lte", "len")
+
 			t := reflect.TypeOf(f.Value()).Elem()
 			if t.String() != "provider.Config" {
-				continue
+				break
 			}
 			d.SubType = typePlugin
 			if hasTagKey(f, "validate", "lte") {
@@ -195,4 +269,4 @@ func Annotate(s interface{}) (ds []FieldMetadata) {
 	}
 
 	return ds
-}
\ No newline at end of file
+}
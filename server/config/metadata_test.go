@@ -0,0 +1,60 @@
+package config
+
+import "testing"
+
+func TestAnnotate(t *testing.T) {
+	type Sample struct {
+		Name  string   `validate:"required"`
+		Level int      `validate:"gte=0,lte=10"`
+		Mode  string   `validate:"oneof=a b c"`
+		Label string   `validate:"gte=1,lte=5" ui:"de=Bezeichnung"`
+		Hide  string   `ui:",hide"`
+		Tags  []string `validate:"gte=1,lte=5"`
+	}
+
+	ds := Annotate(Sample{Name: "n", Level: 3, Mode: "a", Label: "ab", Tags: []string{"a"}})
+
+	byName := make(map[string]FieldMetadata)
+	for _, d := range ds {
+		byName[d.Name] = d
+	}
+
+	name, ok := byName["Name"]
+	if !ok || !name.Required {
+		t.Errorf("Name not marked required: %+v", name)
+	}
+
+	level := byName["Level"]
+	if level.Min == nil || *level.Min != 0 || level.Max == nil || *level.Max != 10 {
+		t.Errorf("Level bounds not propagated: %+v", level)
+	}
+	if level.Step == nil || *level.Step != 1 {
+		t.Errorf("Level.Step = %v, want 1", level.Step)
+	}
+
+	mode := byName["Mode"]
+	if len(mode.Enum) != 3 {
+		t.Errorf("Mode.Enum = %v, want 3 entries", mode.Enum)
+	}
+
+	label := byName["Label"]
+	if label.MinLength == nil || *label.MinLength != 1 || label.MaxLength == nil || *label.MaxLength != 5 {
+		t.Errorf("Label length bounds not propagated: %+v", label)
+	}
+	if label.Label != "Bezeichnung" {
+		t.Errorf("Label.Label = %q, want Bezeichnung", label.Label)
+	}
+
+	hide := byName["Hide"]
+	if !hide.Hidden {
+		t.Errorf("Hide not marked hidden: %+v", hide)
+	}
+
+	tags, ok := byName["Tags"]
+	if !ok {
+		t.Fatal("missing Tags field")
+	}
+	if tags.MinLength == nil || *tags.MinLength != 1 || tags.MaxLength == nil || *tags.MaxLength != 5 {
+		t.Errorf("Tags length bounds not propagated: %+v", tags)
+	}
+}
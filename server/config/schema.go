@@ -0,0 +1,186 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fatih/structs"
+)
+
+// jsonSchema is a single node of a JSON Schema draft-07 document
+type jsonSchema struct {
+	Type       string                 `json:"type,omitempty"`
+	Format     string                 `json:"format,omitempty"`
+	Ref        string                 `json:"$ref,omitempty"`
+	Enum       []interface{}          `json:"enum,omitempty"`
+	Items      *jsonSchema            `json:"items,omitempty"`
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	Maximum    *float64               `json:"maximum,omitempty"`
+	Minimum    *float64               `json:"minimum,omitempty"`
+	MaxItems   *int                   `json:"maxItems,omitempty"`
+	MinItems   *int                   `json:"minItems,omitempty"`
+}
+
+// jsonSchemaDocument is the draft-07 document root
+type jsonSchemaDocument struct {
+	Schema      string                 `json:"$schema"`
+	Type        string                 `json:"type"`
+	Properties  map[string]*jsonSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Definitions map[string]*jsonSchema `json:"definitions,omitempty"`
+}
+
+const jsonSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// pluginSchema is the shared definition for provider.Config fields
+func pluginSchema() *jsonSchema {
+	return &jsonSchema{Type: "object"}
+}
+
+// jsonSchemaType maps a reflect.Kind to its JSON Schema type
+func jsonSchemaType(f *structs.Field) (string, string) {
+	val := f.Value()
+
+	switch {
+	case reflect.TypeOf(val) == reflect.TypeOf(time.Duration(0)):
+		return "integer", "duration-seconds"
+
+	case hasTagKey(f, "ui", "mask"):
+		return "string", typePassword
+
+	case hasTagKey(f, "ui", "text"):
+		return "string", "textarea"
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		return "string", ""
+	case reflect.Bool:
+		return "boolean", ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer", ""
+	case reflect.Float32, reflect.Float64:
+		return "number", ""
+	case reflect.Slice, reflect.Array:
+		return "array", ""
+	case reflect.Struct:
+		return "object", ""
+	case reflect.Ptr:
+		if f.Value() != nil && reflect.TypeOf(f.Value()).Elem().Kind() == reflect.Struct {
+			return "object", ""
+		}
+		return "string", ""
+	default:
+		return "string", ""
+	}
+}
+
+// fieldSchema builds the JSON Schema node for a single struct field
+func fieldSchema(f *structs.Field, definitions map[string]*jsonSchema) *jsonSchema {
+	typ, format := jsonSchemaType(f)
+	s := &jsonSchema{Type: typ, Format: format}
+
+	if oneof := tagKey(f, "validate", "oneof"); oneof != "" {
+		s.Enum = enum(strings.Split(oneof, " "))
+	}
+
+	switch typ {
+	case "integer", "number":
+		s.Minimum = floatTag(f, "gte", "min")
+		s.Maximum = floatTag(f, "lte", "max")
+
+	case "array":
+		s.MinItems = intTag(f, "gte", "len")
+		s.MaxItems = intTag(f, "lte", "len")
+
+		if f.Kind() == reflect.Slice {
+			elem := reflect.TypeOf(f.Value()).Elem()
+			if elem.String() == "provider.Config" {
+				if _, ok := definitions[typePlugin]; !ok {
+					definitions[typePlugin] = pluginSchema()
+				}
+				s.Items = &jsonSchema{Ref: "#/definitions/" + typePlugin}
+				return s
+			}
+		}
+
+	case "object":
+		val := f.Value()
+
+		if f.Kind() == reflect.Ptr {
+			t := reflect.TypeOf(val).Elem()
+			if t.String() == "provider.Config" {
+				if _, ok := definitions[typePlugin]; !ok {
+					definitions[typePlugin] = pluginSchema()
+				}
+				return &jsonSchema{Ref: "#/definitions/" + typePlugin}
+			}
+
+			// structs.Fields panics on a nil pointer; describe the zero value
+			// of the pointed-to type instead, since the field is typically unset
+			if reflect.ValueOf(val).IsNil() {
+				val = reflect.New(t).Interface()
+			}
+		}
+
+		props, required := structSchema(val, definitions)
+		s.Properties = props
+		s.Required = required
+	}
+
+	return s
+}
+
+// structSchema walks a struct via structs.Fields and builds its properties and required list
+func structSchema(s interface{}, definitions map[string]*jsonSchema) (map[string]*jsonSchema, []string) {
+	props := make(map[string]*jsonSchema)
+	var required []string
+
+	for _, f := range structs.Fields(s) {
+		if !f.IsExported() || hasTagKey(f, "ui", "hide") {
+			continue
+		}
+
+		if f.Kind() == reflect.Interface || f.Kind() == reflect.Func {
+			continue
+		}
+
+		// embedded fields are flattened into the parent object
+		if f.Kind() == reflect.Struct && f.IsEmbedded() {
+			embedded, embeddedRequired := structSchema(f.Value(), definitions)
+			for k, v := range embedded {
+				props[k] = v
+			}
+			required = append(required, embeddedRequired...)
+			continue
+		}
+
+		props[f.Name()] = fieldSchema(f, definitions)
+
+		if hasTagKey(f, "validate", "required") {
+			required = append(required, f.Name())
+		}
+	}
+
+	return props, required
+}
+
+// JSONSchema produces a JSON Schema draft-07 document describing the given configuration structure
+func JSONSchema(s interface{}) ([]byte, error) {
+	definitions := make(map[string]*jsonSchema)
+	props, required := structSchema(s, definitions)
+
+	doc := jsonSchemaDocument{
+		Schema:      jsonSchemaDraft07,
+		Type:        "object",
+		Properties:  props,
+		Required:    required,
+		Definitions: definitions,
+	}
+
+	return json.Marshal(doc)
+}
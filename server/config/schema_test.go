@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	type Nested struct {
+		Host string `validate:"required"`
+	}
+
+	type Sample struct {
+		Name    string   `validate:"required"`
+		Level   int      `validate:"gte=0,lte=10"`
+		Mode    string   `validate:"oneof=a b c"`
+		Tags    []string `validate:"gte=1,lte=5"`
+		Nested  Nested
+		NestedP *Nested
+	}
+
+	b, err := JSONSchema(Sample{NestedP: &Nested{Host: "h"}})
+	if err != nil {
+		t.Fatalf("JSONSchema: %v", err)
+	}
+
+	var doc jsonSchemaDocument
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if doc.Type != "object" {
+		t.Errorf("Type = %q, want object", doc.Type)
+	}
+
+	name, ok := doc.Properties["Name"]
+	if !ok {
+		t.Fatal("missing Name property")
+	}
+	if name.Type != "string" {
+		t.Errorf("Name.Type = %q, want string", name.Type)
+	}
+
+	level := doc.Properties["Level"]
+	if level.Type != "integer" || level.Minimum == nil || *level.Minimum != 0 || level.Maximum == nil || *level.Maximum != 10 {
+		t.Errorf("Level bounds not propagated: %+v", level)
+	}
+
+	mode := doc.Properties["Mode"]
+	if len(mode.Enum) != 3 {
+		t.Errorf("Mode.Enum = %v, want 3 entries", mode.Enum)
+	}
+
+	tags := doc.Properties["Tags"]
+	if tags.Type != "array" || tags.MinItems == nil || *tags.MinItems != 1 || tags.MaxItems == nil || *tags.MaxItems != 5 {
+		t.Errorf("Tags bounds not propagated: %+v", tags)
+	}
+
+	nested := doc.Properties["Nested"]
+	if nested.Type != "object" || nested.Properties["Host"] == nil {
+		t.Errorf("Nested struct not expanded: %+v", nested)
+	}
+
+	nestedP := doc.Properties["NestedP"]
+	if nestedP.Type != "object" || nestedP.Properties["Host"] == nil {
+		t.Errorf("pointer-to-struct field not expanded: %+v", nestedP)
+	}
+
+	var required []string
+	required = append(required, doc.Required...)
+	if len(required) != 1 || required[0] != "Name" {
+		t.Errorf("Required = %v, want [Name]", required)
+	}
+}
+
+// TestJSONSchemaNilPointer covers the normal zero-value case of an unset
+// optional pointer-to-struct field, which must describe the pointed-to
+// type's shape rather than panic inside structs.Fields
+func TestJSONSchemaNilPointer(t *testing.T) {
+	type Nested struct {
+		Host string `validate:"required"`
+	}
+
+	type Sample struct {
+		NestedP *Nested
+	}
+
+	b, err := JSONSchema(Sample{})
+	if err != nil {
+		t.Fatalf("JSONSchema: %v", err)
+	}
+
+	var doc jsonSchemaDocument
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	nestedP := doc.Properties["NestedP"]
+	if nestedP == nil || nestedP.Type != "object" || nestedP.Properties["Host"] == nil {
+		t.Errorf("nil pointer-to-struct field not described: %+v", nestedP)
+	}
+}